@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"text/template"
@@ -79,6 +82,40 @@ OPTIONS:
    {{end}}{{end}}
 `
 
+// FlagsByName is a slice of Flag that implements sort.Interface to sort by
+// name. Apps that want deterministic GLOBAL OPTIONS / OPTIONS output without
+// setting App.SortHelp can sort app.Flags (or command.Flags) themselves
+// before calling app.Run, e.g. sort.Sort(cli.FlagsByName(app.Flags)).
+type FlagsByName []Flag
+
+func (f FlagsByName) Len() int {
+	return len(f)
+}
+
+func (f FlagsByName) Less(i, j int) bool {
+	return f[i].GetName() < f[j].GetName()
+}
+
+func (f FlagsByName) Swap(i, j int) {
+	f[i], f[j] = f[j], f[i]
+}
+
+// CommandsByName is a slice of Command that implements sort.Interface to
+// sort by name.
+type CommandsByName []Command
+
+func (c CommandsByName) Len() int {
+	return len(c)
+}
+
+func (c CommandsByName) Less(i, j int) bool {
+	return c[i].Name < c[j].Name
+}
+
+func (c CommandsByName) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
 var helpCommand = &Command{
 	Name:      "help",
 	Aliases:   []string{"h"},
@@ -134,8 +171,85 @@ func ShowAppHelpAndExit(c *Context, exitCode int) {
 	os.Exit(exitCode)
 }
 
+// HelpFormatFlag is a hidden flag that renders help as a man page or as
+// Markdown instead of printing the usual text template. It is read by
+// ShowAppHelp and checkCommandHelp whenever help is shown, so that
+// `myapp --help --help-format man` and `myapp --help --help-format markdown`
+// work anywhere `--help` does, letting distro
+// packagers generate myapp.1 directly from the built binary instead of
+// maintaining a second source of truth.
+var HelpFormatFlag = StringFlag{
+	Name:   "help-format",
+	Usage:  "render help as `FORMAT` (man, markdown) instead of printing it",
+	Hidden: true,
+}
+
+// helpOutputFormat returns the value of the hidden --help-format flag, or
+// "" if it was not set, meaning help should render as plain text as usual.
+func helpOutputFormat(c *Context) string {
+	if !c.IsSet(HelpFormatFlag.Name) {
+		return ""
+	}
+	return c.String(HelpFormatFlag.Name)
+}
+
+func writeHelpFormat(w io.Writer, format string, app *App) error {
+	var (
+		out string
+		err error
+	)
+	switch format {
+	case "man":
+		out, err = ToMan(app)
+	case "markdown":
+		out, err = ToMarkdown(app)
+	default:
+		return fmt.Errorf("--help-format value cannot be '%s'", format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, out)
+	return err
+}
+
+// writeCommandHelpFormat is writeHelpFormat's command-scoped counterpart:
+// it renders only command (and its subcommands/flags), matching the way
+// ShowCommandHelp scopes CommandHelpTemplate to a single command instead
+// of falling back to whole-app output.
+func writeCommandHelpFormat(w io.Writer, format string, app *App, command *Command) error {
+	var (
+		out string
+		err error
+	)
+	if app.SortHelp {
+		sort.Sort(FlagsByName(command.Flags))
+		sort.Sort(CommandsByName(command.Subcommands))
+	}
+	switch format {
+	case "man":
+		out, err = toManCommand(app, command)
+	case "markdown":
+		out, err = toMarkdownCommand(app, command)
+	default:
+		return fmt.Errorf("--help-format value cannot be '%s'", format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, out)
+	return err
+}
+
 // ShowAppHelp is an action that displays the help.
 func ShowAppHelp(c *Context) (err error) {
+	if c.App.SortHelp {
+		sort.Sort(FlagsByName(c.App.Flags))
+		sort.Sort(CommandsByName(c.App.Commands))
+	}
+	if format := helpOutputFormat(c); format != "" {
+		return writeHelpFormat(c.App.Writer, format, c.App)
+	}
 	if c.App.CustomAppHelpTemplate == "" {
 		HelpPrinter(c.App.Writer, AppHelpTemplate, c.App)
 		return
@@ -162,11 +276,16 @@ func printCommandSuggestions(commands []Command, writer io.Writer) {
 		if command.Hidden {
 			continue
 		}
-		if os.Getenv("_CLI_ZSH_AUTOCOMPLETE_HACK") == "1" {
+		switch {
+		case os.Getenv("_CLI_ZSH_AUTOCOMPLETE_HACK") == "1":
 			for _, name := range command.Names() {
 				_, _ = fmt.Fprintf(writer, "%s:%s\n", name, command.Usage)
 			}
-		} else {
+		case os.Getenv("_CLI_FISH_AUTOCOMPLETE") == "1", os.Getenv("_CLI_PWSH_AUTOCOMPLETE") == "1":
+			for _, name := range command.Names() {
+				_, _ = fmt.Fprintf(writer, "%s\t%s\n", name, command.Usage)
+			}
+		default:
 			for _, name := range command.Names() {
 				_, _ = fmt.Fprintf(writer, "%s\n", name)
 			}
@@ -191,6 +310,14 @@ func cliArgContains(flagName string) bool {
 	return false
 }
 
+// flagUsage returns a description for flag to show alongside its name in
+// fish/PowerShell completion. Like manWriteFlags/mdWriteFlags it reuses
+// flag.String() rather than type-switching over individual flag types, so
+// it works for every Flag implementation, not just the ones it knows about.
+func flagUsage(flag Flag) string {
+	return flag.String()
+}
+
 func printFlagSuggestions(lastArg string, flags []Flag, writer io.Writer) {
 	cur := strings.TrimPrefix(lastArg, "-")
 	cur = strings.TrimPrefix(cur, "-")
@@ -213,17 +340,150 @@ func printFlagSuggestions(lastArg string, flags []Flag, writer io.Writer) {
 			// match if last argument matches this flag and it is not repeated
 			if strings.HasPrefix(name, cur) && cur != name && !cliArgContains(flag.GetName()) {
 				flagCompletion := fmt.Sprintf("%s%s", strings.Repeat("-", count), name)
+				if os.Getenv("_CLI_FISH_AUTOCOMPLETE") == "1" || os.Getenv("_CLI_PWSH_AUTOCOMPLETE") == "1" {
+					flagCompletion = fmt.Sprintf("%s\t%s", flagCompletion, flagUsage(flag))
+				}
 				_, _ = fmt.Fprintln(writer, flagCompletion)
 			}
 		}
 	}
 }
 
+// completionPartialValueEnv is the env var the generated bash/zsh/fish/pwsh
+// completion snippets set to the word currently being typed, since that
+// word is otherwise excluded from the argv forwarded to the binary.
+const completionPartialValueEnv = "_CLI_COMPLETION_PARTIAL_VALUE"
+
+// CompletableFlag is implemented by flags that can suggest values for shell
+// completion. Once the user has typed a flag's full name and is now
+// completing its value (e.g. "--region <TAB>"), DefaultCompleteWithFlags
+// calls GetValueCompletion with what the user has typed of the value so
+// far instead of listing further flag names.
+type CompletableFlag interface {
+	Flag
+	GetValueCompletion(c *Context, partial string) []string
+}
+
+// CompletableStringFlag wraps StringFlag with a ValueCompletion hook, so an
+// app can get `--config <TAB>` completion by using it in place of a plain
+// StringFlag instead of hand-writing a custom Flag implementation:
+//
+//	cli.CompletableStringFlag{
+//		StringFlag:      cli.StringFlag{Name: "config"},
+//		ValueCompletion: cli.FileCompletion,
+//	}
+type CompletableStringFlag struct {
+	StringFlag
+	ValueCompletion func(c *Context, partial string) []string
+}
+
+// GetValueCompletion implements CompletableFlag.
+func (f CompletableStringFlag) GetValueCompletion(c *Context, partial string) []string {
+	if f.ValueCompletion == nil {
+		return nil
+	}
+	return f.ValueCompletion(c, partial)
+}
+
+// FileCompletion is a ValueCompletion hook that suggests filesystem paths
+// matching partial, for flags like --config <TAB>.
+func FileCompletion(c *Context, partial string) []string {
+	return globCompletion(partial, false)
+}
+
+// DirCompletion is a ValueCompletion hook that suggests directories
+// matching partial, for flags like --output-dir <TAB>.
+func DirCompletion(c *Context, partial string) []string {
+	return globCompletion(partial, true)
+}
+
+func globCompletion(partial string, dirsOnly bool) []string {
+	matches, err := filepath.Glob(partial + "*")
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, match := range matches {
+		if dirsOnly {
+			info, err := os.Stat(match)
+			if err != nil || !info.IsDir() {
+				continue
+			}
+		}
+		out = append(out, match)
+	}
+	return out
+}
+
+// ChoiceCompletion returns a ValueCompletion hook that suggests the
+// elements of opts matching partial, for flags restricted to a fixed set
+// of values (e.g. --log-level <TAB>).
+func ChoiceCompletion(opts ...string) func(c *Context, partial string) []string {
+	return func(c *Context, partial string) []string {
+		var out []string
+		for _, opt := range opts {
+			if strings.HasPrefix(opt, partial) {
+				out = append(out, opt)
+			}
+		}
+		return out
+	}
+}
+
+// flagTokenMatches reports whether token, as typed on the command line
+// (e.g. "--region"), exactly names flag.
+func flagTokenMatches(token string, flag Flag) bool {
+	trimmed := strings.TrimLeft(token, "-")
+	for _, name := range strings.Split(flag.GetName(), ",") {
+		if strings.TrimSpace(name) == trimmed {
+			return true
+		}
+	}
+	return false
+}
+
+// completableFlagFor returns the CompletableFlag among flags that lastArg
+// names exactly, provided it isn't a bool flag: bool flags take no value,
+// so the next argument on the command line is never their value.
+func completableFlagFor(lastArg string, flags []Flag) CompletableFlag {
+	if !strings.HasPrefix(lastArg, "-") {
+		return nil
+	}
+	for _, flag := range flags {
+		if _, ok := flag.(BoolFlag); ok {
+			continue
+		}
+		if !flagTokenMatches(lastArg, flag) {
+			continue
+		}
+		if cf, ok := flag.(CompletableFlag); ok {
+			return cf
+		}
+	}
+	return nil
+}
+
 func DefaultCompleteWithFlags(cmd *Command) func(c *Context) {
 	return func(c *Context) {
 		if len(os.Args) > 2 {
 			lastArg := os.Args[len(os.Args)-2]
 			if strings.HasPrefix(lastArg, "-") {
+				flags := c.App.Flags
+				if cmd != nil {
+					flags = append(append([]Flag{}, c.App.Flags...), cmd.Flags...)
+				}
+				if cf := completableFlagFor(lastArg, flags); cf != nil {
+					// The in-progress word is deliberately excluded from
+					// the forwarded argv by every shell template (it's
+					// always either absent or the completion sentinel),
+					// so it has to be carried separately; the generated
+					// shell snippets stash it in this env var instead.
+					partial := os.Getenv(completionPartialValueEnv)
+					for _, value := range cf.GetValueCompletion(c, partial) {
+						_, _ = fmt.Fprintln(c.App.Writer, value)
+					}
+					return
+				}
 				printFlagSuggestions(lastArg, c.App.Flags, c.App.Writer)
 				if cmd != nil {
 					printFlagSuggestions(lastArg, cmd.Flags, c.App.Writer)
@@ -249,12 +509,23 @@ func ShowCommandHelpAndExit(c *Context, command string, code int) {
 func ShowCommandHelp(ctx *Context, command string) error {
 	// show the subcommand help for a command with subcommands
 	if command == "" {
+		if ctx.App.SortHelp {
+			sort.Sort(FlagsByName(ctx.App.Flags))
+			sort.Sort(CommandsByName(ctx.App.Commands))
+		}
 		HelpPrinter(ctx.App.Writer, SubcommandHelpTemplate, ctx.App)
 		return nil
 	}
 
+	if ctx.App.SortHelp {
+		sort.Sort(CommandsByName(ctx.App.Commands))
+	}
+
 	for _, c := range ctx.App.Commands {
 		if c.HasName(command) {
+			if ctx.App.SortHelp {
+				sort.Sort(FlagsByName(c.Flags))
+			}
 			if c.CustomHelpTemplate != "" {
 				HelpPrinterCustom(ctx.App.Writer, c.CustomHelpTemplate, c, nil)
 			} else {
@@ -370,6 +641,14 @@ func checkHelp(c *Context) bool {
 
 func checkCommandHelp(c *Context, name string) bool {
 	if c.Bool("h") || c.Bool("help") {
+		if format := helpOutputFormat(c); format != "" {
+			if cmd := c.App.Command(name); cmd != nil {
+				_ = writeCommandHelpFormat(c.App.Writer, format, c.App, cmd)
+			} else {
+				_ = writeHelpFormat(c.App.Writer, format, c.App)
+			}
+			return true
+		}
 		_ = ShowCommandHelp(c, name)
 		return true
 	}
@@ -438,6 +717,12 @@ func checkInitCompletion(c *Context) (bool, error) {
 		case "zsh":
 			fmt.Print(zshCompletionCode(progName))
 			return true, nil
+		case "fish":
+			fmt.Print(fishCompletionCode(progName))
+			return true, nil
+		case "pwsh", "powershell":
+			fmt.Print(pwshCompletionCode(progName))
+			return true, nil
 		default:
 			return false, fmt.Errorf("--init-completion value cannot be '%s'", shell)
 		}
@@ -450,12 +735,12 @@ func bashCompletionCode(progName string) string {
      local cur opts base;
      COMPREPLY=();
      cur="${COMP_WORDS[COMP_CWORD]}";
-     opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --%s );
+     opts=$( %[3]s="${cur}" ${COMP_WORDS[@]:0:$COMP_CWORD} --%[1]s );
      COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) );
      return 0;
 };
-complete -F _cli_bash_autocomplete %s`
-	return fmt.Sprintf(template, genCompName(), progName)
+complete -F _cli_bash_autocomplete %[2]s`
+	return fmt.Sprintf(template, genCompName(), progName, completionPartialValueEnv)
 }
 
 func zshCompletionCode(progName string) string {
@@ -464,3 +749,263 @@ autoload -U bashcompinit && bashcompinit;`
 
 	return template + "\n" + bashCompletionCode(progName)
 }
+
+func fishCompletionCode(progName string) string {
+	// (commandline -opc) expands to the typed command line up to the word
+	// being completed, whose first token is progName itself; dropping that
+	// token with [2..-1] (rather than passing progName twice) keeps the
+	// forwarded argv aligned with what bash's COMP_WORDS[@]:0:$COMP_CWORD
+	// produces.
+	var template = `function __%[1]s_complete
+    set -lx _CLI_FISH_AUTOCOMPLETE 1
+    set -lx %[3]s (commandline -ct)
+    %[1]s (commandline -opc)[2..-1] --%[2]s
+end
+complete -c %[1]s -f -a '(__%[1]s_complete)'`
+	return fmt.Sprintf(template, progName, genCompName(), completionPartialValueEnv)
+}
+
+func pwshCompletionCode(progName string) string {
+	// $commandAst.CommandElements' first element is progName itself, so it
+	// is dropped before being passed to "& %[1]s" to avoid invoking the
+	// binary with its own name as the first positional argument. A non-empty
+	// word being completed is also one of the elements; it is dropped too,
+	// as it is passed in the partial-value env var instead.
+	var template = `Register-ArgumentCompleter -Native -CommandName %[1]s -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $env:_CLI_PWSH_AUTOCOMPLETE = "1"
+    $env:%[3]s = $wordToComplete
+    $words = ($commandAst.CommandElements | ForEach-Object { $_.ToString() }) | Select-Object -Skip 1
+    if ($wordToComplete) { $words = $words | Select-Object -SkipLast 1 }
+    (& %[1]s $words --%[2]s) | ForEach-Object {
+        $name, $tooltip = $_ -split "\t", 2
+        [System.Management.Automation.CompletionResult]::new($name, $name, 'ParameterValue', $(if ($tooltip) { $tooltip } else { $name }))
+    }
+}`
+	return fmt.Sprintf(template, progName, genCompName(), completionPartialValueEnv)
+}
+
+// ToMan creates a groff-formatted man page (section 1) for app and returns
+// it as a string. It walks the same App/Command model used to render
+// AppHelpTemplate, so packagers can generate myapp.1 directly from the
+// built binary (`myapp --help --help-format man > myapp.1`) instead of maintaining
+// a second source of truth.
+func ToMan(app *App) (string, error) {
+	var w bytes.Buffer
+
+	fmt.Fprintf(&w, ".TH %s 1\n", manEscape(strings.ToUpper(app.Name)))
+	fmt.Fprintf(&w, ".SH NAME\n%s", manEscape(app.Name))
+	if app.Usage != "" {
+		fmt.Fprintf(&w, " \\- %s", manEscape(app.Usage))
+	}
+	fmt.Fprint(&w, "\n")
+
+	fmt.Fprintf(&w, ".SH SYNOPSIS\n.B %s\n[global options] command [command options] [arguments...]\n", manEscape(app.Name))
+
+	if app.Description != "" {
+		fmt.Fprintf(&w, ".SH DESCRIPTION\n%s\n", manEscape(app.Description))
+	}
+
+	if len(app.Flags) > 0 {
+		fmt.Fprint(&w, ".SH GLOBAL OPTIONS\n")
+		manWriteFlags(&w, app.Flags)
+	}
+
+	if len(app.Commands) > 0 {
+		fmt.Fprint(&w, ".SH COMMANDS\n")
+		manWriteCommands(&w, app.Commands, "")
+	}
+
+	if len(app.Authors) > 0 {
+		fmt.Fprint(&w, ".SH AUTHOR\n")
+		for _, author := range app.Authors {
+			fmt.Fprintf(&w, "%s\n", manEscape(author.String()))
+		}
+	}
+
+	if app.Copyright != "" {
+		fmt.Fprintf(&w, ".SH COPYRIGHT\n%s\n", manEscape(app.Copyright))
+	}
+
+	return w.String(), nil
+}
+
+// toManCommand is ToMan's command-scoped counterpart: it renders a groff
+// fragment for a single command instead of the whole app, the same way
+// CommandHelpTemplate scopes text help to one command.
+func toManCommand(app *App, command *Command) (string, error) {
+	var w bytes.Buffer
+
+	fmt.Fprintf(&w, ".TH %s 1\n", manEscape(strings.ToUpper(app.Name+"-"+command.Name)))
+	fmt.Fprintf(&w, ".SH NAME\n%s %s", manEscape(app.Name), manEscape(command.Name))
+	if command.Usage != "" {
+		fmt.Fprintf(&w, " \\- %s", manEscape(command.Usage))
+	}
+	fmt.Fprint(&w, "\n")
+
+	fmt.Fprintf(&w, ".SH SYNOPSIS\n.B %s %s\n[command options] [arguments...]\n", manEscape(app.Name), manEscape(command.Name))
+
+	if command.Description != "" {
+		fmt.Fprintf(&w, ".SH DESCRIPTION\n%s\n", manEscape(command.Description))
+	}
+
+	if len(command.Flags) > 0 {
+		fmt.Fprint(&w, ".SH OPTIONS\n")
+		manWriteFlags(&w, command.Flags)
+	}
+
+	if len(command.Subcommands) > 0 {
+		fmt.Fprint(&w, ".SH COMMANDS\n")
+		manWriteCommands(&w, command.Subcommands, "")
+	}
+
+	return w.String(), nil
+}
+
+func manWriteFlags(w io.Writer, flags []Flag) {
+	for _, flag := range flags {
+		fmt.Fprintf(w, ".TP\n%s\n", manEscape(flag.String()))
+	}
+}
+
+func manWriteCommands(w io.Writer, commands []Command, prefix string) {
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		fmt.Fprintf(w, ".TP\n\\fB%s%s\\fR\n", manEscape(prefix), manEscape(strings.Join(command.Names(), ", ")))
+		if command.Usage != "" {
+			fmt.Fprintf(w, "%s\n", manEscape(command.Usage))
+		}
+		if len(command.Flags) > 0 {
+			manWriteFlags(w, command.Flags)
+		}
+		if len(command.Subcommands) > 0 {
+			manWriteCommands(w, command.Subcommands, prefix+command.Name+" ")
+		}
+	}
+}
+
+// manEscape escapes characters meaningful to groff: backslashes (used for
+// font/special-char requests) and hyphens (which troff otherwise treats as
+// soft hyphens).
+func manEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	return s
+}
+
+// ToMarkdown creates a GitHub-flavored Markdown string for app and returns
+// it as a string. Like ToMan it walks the App/Command model directly, so
+// the generated docs can never drift from the flags and commands the
+// binary actually understands.
+func ToMarkdown(app *App) (string, error) {
+	var w bytes.Buffer
+
+	fmt.Fprintf(&w, "# %s\n\n", mdEscape(app.Name))
+	if app.Usage != "" {
+		fmt.Fprintf(&w, "%s\n\n", mdEscape(app.Usage))
+	}
+	if app.Description != "" {
+		fmt.Fprintf(&w, "%s\n\n", mdEscape(app.Description))
+	}
+
+	fmt.Fprintf(&w, "## USAGE\n\n```\n%s [global options] command [command options] [arguments...]\n```\n\n", app.Name)
+
+	if len(app.Flags) > 0 {
+		fmt.Fprint(&w, "## GLOBAL OPTIONS\n\n")
+		mdWriteFlags(&w, app.Flags)
+		fmt.Fprint(&w, "\n")
+	}
+
+	if len(app.Commands) > 0 {
+		fmt.Fprint(&w, "## COMMANDS\n\n")
+		mdWriteCommands(&w, app.Commands, "")
+		fmt.Fprint(&w, "\n")
+	}
+
+	if len(app.Authors) > 0 {
+		fmt.Fprint(&w, "## AUTHOR\n\n")
+		for _, author := range app.Authors {
+			fmt.Fprintf(&w, "* %s\n", mdEscape(author.String()))
+		}
+		fmt.Fprint(&w, "\n")
+	}
+
+	if app.Copyright != "" {
+		fmt.Fprintf(&w, "## COPYRIGHT\n\n%s\n", mdEscape(app.Copyright))
+	}
+
+	return w.String(), nil
+}
+
+// toMarkdownCommand is ToMarkdown's command-scoped counterpart: it renders
+// only command instead of the whole app.
+func toMarkdownCommand(app *App, command *Command) (string, error) {
+	var w bytes.Buffer
+
+	fmt.Fprintf(&w, "# %s %s\n\n", mdEscape(app.Name), mdEscape(command.Name))
+	if command.Usage != "" {
+		fmt.Fprintf(&w, "%s\n\n", mdEscape(command.Usage))
+	}
+	if command.Description != "" {
+		fmt.Fprintf(&w, "%s\n\n", mdEscape(command.Description))
+	}
+
+	fmt.Fprintf(&w, "## USAGE\n\n```\n%s %s [command options] [arguments...]\n```\n\n", app.Name, command.Name)
+
+	if len(command.Flags) > 0 {
+		fmt.Fprint(&w, "## OPTIONS\n\n")
+		mdWriteFlags(&w, command.Flags)
+		fmt.Fprint(&w, "\n")
+	}
+
+	if len(command.Subcommands) > 0 {
+		fmt.Fprint(&w, "## COMMANDS\n\n")
+		mdWriteCommands(&w, command.Subcommands, "")
+	}
+
+	return w.String(), nil
+}
+
+func mdWriteFlags(w io.Writer, flags []Flag) {
+	for _, flag := range flags {
+		fmt.Fprintf(w, "* `%s`\n", mdEscapeCode(flag.String()))
+	}
+}
+
+func mdWriteCommands(w io.Writer, commands []Command, prefix string) {
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		fmt.Fprintf(w, "%s* `%s`", prefix, strings.Join(command.Names(), ", "))
+		if command.Usage != "" {
+			fmt.Fprintf(w, ": %s", mdEscape(command.Usage))
+		}
+		fmt.Fprint(w, "\n")
+		if len(command.Subcommands) > 0 {
+			mdWriteCommands(w, command.Subcommands, prefix+"  ")
+		}
+	}
+}
+
+// mdEscape escapes characters meaningful to Markdown so usage strings,
+// descriptions and other prose render as plain text rather than triggering
+// emphasis (flags, rendered inside code spans, use mdEscapeCode instead). A
+// backtick can't be neutralized with a leading backslash the way * and _
+// can (code spans in CommonMark don't honor backslash-escapes), so it's
+// replaced with its HTML entity instead of a different character, which
+// renders identically to a literal backtick without reopening a code span.
+func mdEscape(s string) string {
+	replacer := strings.NewReplacer(
+		"*", `\*`,
+		"_", `\_`,
+		"`", "&#96;",
+	)
+	return replacer.Replace(s)
+}
+
+func mdEscapeCode(s string) string {
+	return strings.ReplaceAll(s, "`", "&#96;")
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCompletableStringFlag_ValueCompletion(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	oldPartial := os.Getenv(completionPartialValueEnv)
+	defer os.Setenv(completionPartialValueEnv, oldPartial)
+
+	cf := CompletableStringFlag{
+		StringFlag:      StringFlag{Name: "config"},
+		ValueCompletion: ChoiceCompletion("alpha.yaml", "beta.yaml", "gamma.yaml"),
+	}
+
+	app := &App{Flags: []Flag{cf}}
+	buf := &bytes.Buffer{}
+	app.Writer = buf
+	set := flag.NewFlagSet("test", 0)
+	ctx := NewContext(app, set, nil)
+
+	// Simulate "myapp --config <TAB>" where the user has typed "a" so far.
+	os.Args = []string{"myapp", "--config", "--" + genCompName()}
+	_ = os.Setenv(completionPartialValueEnv, "a")
+
+	DefaultCompleteWithFlags(nil)(ctx)
+
+	got := buf.String()
+	if !strings.Contains(got, "alpha.yaml") {
+		t.Errorf("expected alpha.yaml to be suggested for --config, got %q", got)
+	}
+	if strings.Contains(got, "beta.yaml") || strings.Contains(got, "gamma.yaml") {
+		t.Errorf("expected only the \"a\"-prefixed choice to be suggested, got %q", got)
+	}
+}
+
+func TestFlagUsage_NonStringFlagTypes(t *testing.T) {
+	flags := []Flag{
+		IntFlag{Name: "retries", Usage: "number of retries"},
+		DurationFlag{Name: "timeout", Usage: "request timeout"},
+		Float64Flag{Name: "ratio", Usage: "sampling ratio"},
+	}
+	for _, f := range flags {
+		got := flagUsage(f)
+		if got == "" {
+			t.Errorf("flagUsage(%T) returned an empty description, want flag.String() output", f)
+		}
+		if got != f.String() {
+			t.Errorf("flagUsage(%T) = %q, want flag.String() = %q", f, got, f.String())
+		}
+	}
+}
+
+func TestMdEscape_PreservesBacktickedText(t *testing.T) {
+	got := mdEscape("use `--flag` carefully")
+	if strings.Contains(got, "`") {
+		t.Errorf("mdEscape left a literal backtick, which would reopen a code span: %q", got)
+	}
+	if !strings.Contains(got, "--flag") {
+		t.Errorf("mdEscape corrupted the original text: %q", got)
+	}
+}
+
+func TestMdEscapeCode_PreservesBacktickedText(t *testing.T) {
+	got := mdEscapeCode("--name `value`")
+	if strings.Contains(got, "`") {
+		t.Errorf("mdEscapeCode left a literal backtick, which would break the enclosing code span: %q", got)
+	}
+	if !strings.Contains(got, "--name") || !strings.Contains(got, "value") {
+		t.Errorf("mdEscapeCode corrupted the original text: %q", got)
+	}
+}
+
+func testApp() *App {
+	return &App{
+		Name:        "myapp",
+		Usage:       "does things",
+		Description: "myapp does a lot of things for you",
+		Flags: []Flag{
+			StringFlag{Name: "config", Usage: "load config from `FILE`"},
+		},
+		Commands: []Command{
+			{Name: "run", Usage: "run the thing"},
+			{Name: "secret", Usage: "not for public consumption", Hidden: true},
+		},
+		Authors: []Author{
+			{Name: "Jane Doe", Email: "jane@example.com"},
+		},
+		Copyright: "(c) 2026 Example Corp",
+	}
+}
+
+func testCommand() *Command {
+	return &Command{
+		Name:        "run",
+		Usage:       "run the thing",
+		Description: "run executes the thing with the given options",
+		Flags: []Flag{
+			BoolFlag{Name: "force", Usage: "skip confirmation"},
+		},
+	}
+}
+
+func TestToMan_AppLevel(t *testing.T) {
+	got, err := ToMan(testApp())
+	if err != nil {
+		t.Fatalf("ToMan returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		".TH MYAPP 1",
+		".SH NAME\nmyapp \\- does things",
+		".SH SYNOPSIS",
+		".SH DESCRIPTION\nmyapp does a lot of things for you",
+		".SH GLOBAL OPTIONS",
+		".SH COMMANDS",
+		"run",
+		".SH AUTHOR",
+		"Jane Doe",
+		".SH COPYRIGHT\n(c) 2026 Example Corp",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToMan output missing %q, got:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "secret") {
+		t.Errorf("ToMan output should not list the hidden \"secret\" command, got:\n%s", got)
+	}
+}
+
+func TestToManCommand_ScopedToCommand(t *testing.T) {
+	app := testApp()
+	got, err := toManCommand(app, testCommand())
+	if err != nil {
+		t.Fatalf("toManCommand returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		".TH MYAPP-RUN 1",
+		".SH NAME\nmyapp run \\- run the thing",
+		".SH SYNOPSIS\n.B myapp run",
+		".SH DESCRIPTION\nrun executes the thing with the given options",
+		".SH OPTIONS",
+		"force",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("toManCommand output missing %q, got:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, ".SH COMMANDS") || strings.Contains(got, ".SH GLOBAL OPTIONS") {
+		t.Errorf("toManCommand should not render the app's own commands/global options, got:\n%s", got)
+	}
+	if strings.Contains(got, "config") {
+		t.Errorf("toManCommand leaked the app-level --config flag into command output, got:\n%s", got)
+	}
+}
+
+func TestToMarkdown_AppLevel(t *testing.T) {
+	got, err := ToMarkdown(testApp())
+	if err != nil {
+		t.Fatalf("ToMarkdown returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# myapp",
+		"does things",
+		"myapp does a lot of things for you",
+		"## USAGE",
+		"## GLOBAL OPTIONS",
+		"## COMMANDS",
+		"* `run`: run the thing",
+		"## AUTHOR",
+		"Jane Doe",
+		"## COPYRIGHT",
+		"(c) 2026 Example Corp",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToMarkdown output missing %q, got:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "secret") {
+		t.Errorf("ToMarkdown output should not list the hidden \"secret\" command, got:\n%s", got)
+	}
+}
+
+func TestToMarkdownCommand_ScopedToCommand(t *testing.T) {
+	app := testApp()
+	got, err := toMarkdownCommand(app, testCommand())
+	if err != nil {
+		t.Fatalf("toMarkdownCommand returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# myapp run",
+		"run the thing",
+		"run executes the thing with the given options",
+		"## USAGE",
+		"## OPTIONS",
+		"force",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("toMarkdownCommand output missing %q, got:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "## COMMANDS") || strings.Contains(got, "## GLOBAL OPTIONS") {
+		t.Errorf("toMarkdownCommand should not render the app's own commands/global options, got:\n%s", got)
+	}
+	if strings.Contains(got, "config") {
+		t.Errorf("toMarkdownCommand leaked the app-level --config flag into command output, got:\n%s", got)
+	}
+}
+
+func TestFlagsByNameSort(t *testing.T) {
+	flags := FlagsByName{
+		StringFlag{Name: "zeta"},
+		StringFlag{Name: "alpha", Category: "group-a"},
+		StringFlag{Name: "mike", Category: "group-a"},
+	}
+
+	sort.Sort(flags)
+
+	gotNames := make([]string, len(flags))
+	for i, f := range flags {
+		gotNames[i] = f.GetName()
+	}
+	wantNames := []string{"alpha", "mike", "zeta"}
+	if !reflect.DeepEqual(gotNames, wantNames) {
+		t.Errorf("FlagsByName sort = %v, want %v", gotNames, wantNames)
+	}
+
+	// A flag's own fields, including Category, must travel with it rather
+	// than being left behind by a swap that only moves part of the struct.
+	for _, f := range flags {
+		sf := f.(StringFlag)
+		if sf.Name == "alpha" || sf.Name == "mike" {
+			if sf.Category != "group-a" {
+				t.Errorf("flag %q lost its Category during sort, got %q", sf.Name, sf.Category)
+			}
+		}
+	}
+}
+
+func TestCommandsByNameSort(t *testing.T) {
+	commands := CommandsByName{
+		{Name: "zz", Category: "group-z"},
+		{Name: "aa", Category: "group-a"},
+		{Name: "mm", Category: "group-a"},
+	}
+
+	sort.Sort(commands)
+
+	gotNames := make([]string, len(commands))
+	for i, c := range commands {
+		gotNames[i] = c.Name
+	}
+	wantNames := []string{"aa", "mm", "zz"}
+	if !reflect.DeepEqual(gotNames, wantNames) {
+		t.Errorf("CommandsByName sort = %v, want %v", gotNames, wantNames)
+	}
+
+	// Each command's Category must stay attached to its own Name after the
+	// flat sort reorders the slice - sorting by name alone doesn't group by
+	// category, but it also must not scramble which command owns which
+	// category.
+	for _, c := range commands {
+		switch c.Name {
+		case "aa", "mm":
+			if c.Category != "group-a" {
+				t.Errorf("command %q lost its Category during sort, got %q", c.Name, c.Category)
+			}
+		case "zz":
+			if c.Category != "group-z" {
+				t.Errorf("command %q lost its Category during sort, got %q", c.Name, c.Category)
+			}
+		}
+	}
+}
+
+func TestShowAppHelp_SortHelp_SortsBeforeHelpFormat(t *testing.T) {
+	app := &App{
+		Name:     "myapp",
+		SortHelp: true,
+		Flags: []Flag{
+			StringFlag{Name: "zeta"},
+			StringFlag{Name: "alpha"},
+			StringFlag{Name: "mike"},
+		},
+		Commands: []Command{
+			{Name: "zz", Usage: "zz command"},
+			{Name: "aa", Usage: "aa command"},
+		},
+	}
+	buf := &bytes.Buffer{}
+	app.Writer = buf
+
+	set := flag.NewFlagSet("test", 0)
+	set.String(HelpFormatFlag.Name, "", "")
+	if err := set.Parse([]string{"-" + HelpFormatFlag.Name + "=man"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	ctx := NewContext(app, set, nil)
+
+	if err := ShowAppHelp(ctx); err != nil {
+		t.Fatalf("ShowAppHelp returned error: %v", err)
+	}
+
+	got := buf.String()
+	alphaIdx := strings.Index(got, "alpha")
+	mikeIdx := strings.Index(got, "mike")
+	zetaIdx := strings.Index(got, "zeta")
+	if alphaIdx == -1 || mikeIdx == -1 || zetaIdx == -1 {
+		t.Fatalf("expected man output to list all three flags, got:\n%s", got)
+	}
+	if !(alphaIdx < mikeIdx && mikeIdx < zetaIdx) {
+		t.Errorf("man output flags are not alphabetized (alpha=%d, mike=%d, zeta=%d):\n%s", alphaIdx, mikeIdx, zetaIdx, got)
+	}
+
+	aaIdx := strings.Index(got, "aa command")
+	zzIdx := strings.Index(got, "zz command")
+	if aaIdx == -1 || zzIdx == -1 {
+		t.Fatalf("expected man output to list both commands, got:\n%s", got)
+	}
+	if !(aaIdx < zzIdx) {
+		t.Errorf("man output commands are not alphabetized (aa=%d, zz=%d):\n%s", aaIdx, zzIdx, got)
+	}
+}